@@ -0,0 +1,1218 @@
+package eventgrid
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/jcanizalez/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/eventgrid/parse"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceEventGridSystemTopicEventSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEventGridSystemTopicEventSubscriptionCreateUpdate,
+		Read:   resourceEventGridSystemTopicEventSubscriptionRead,
+		Update: resourceEventGridSystemTopicEventSubscriptionCreateUpdate,
+		Delete: resourceEventGridSystemTopicEventSubscriptionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: azSchema.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.SystemTopicEventSubscriptionID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringMatch(
+						regexp.MustCompile("^[-a-zA-Z0-9]{3,128}$"),
+						"EventGrid Event Subscription name must be 3 - 128 characters long, contain only letters, numbers and hyphens.",
+					),
+				),
+			},
+
+			"system_topic": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"event_delivery_schema": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(eventgrid.EventGridSchema),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(eventgrid.CloudEventSchemaV10),
+					string(eventgrid.CustomInputSchema),
+					string(eventgrid.EventGridSchema),
+				}, false),
+			},
+
+			"expiration_time_utc": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"azure_function_endpoint": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"function_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"max_events_per_batch": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 5000),
+						},
+
+						"preferred_batch_size_in_kilobytes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      64,
+							ValidateFunc: validation.IntBetween(1, 1024),
+						},
+					},
+				},
+			},
+
+			"eventhub_endpoint_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"hybrid_connection_endpoint_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"service_bus_queue_endpoint_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"service_bus_topic_endpoint_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"storage_queue_endpoint": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"queue_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"queue_message_time_to_live_in_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
+			"webhook_endpoint": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: eventSubscriptionEndpointProperties,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+						},
+
+						"base_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"max_events_per_batch": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 5000),
+						},
+
+						"preferred_batch_size_in_kilobytes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      64,
+							ValidateFunc: validation.IntBetween(1, 1024),
+						},
+
+						"active_directory_tenant_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+
+						"active_directory_app_id_or_uri": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"included_event_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"subject_filter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subject_begins_with": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"subject_ends_with": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"case_sensitive": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"advanced_filter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bool_equals":                   advancedFilterSchema(schema.TypeBool, 1),
+						"number_greater_than":           advancedFilterSchema(schema.TypeFloat, 1),
+						"number_greater_than_or_equals": advancedFilterSchema(schema.TypeFloat, 1),
+						"number_less_than":              advancedFilterSchema(schema.TypeFloat, 1),
+						"number_less_than_or_equals":    advancedFilterSchema(schema.TypeFloat, 1),
+						"number_in":                     advancedFilterSchema(schema.TypeFloat, 5),
+						"number_not_in":                 advancedFilterSchema(schema.TypeFloat, 5),
+						"string_begins_with":            advancedFilterSchema(schema.TypeString, 5),
+						"string_ends_with":              advancedFilterSchema(schema.TypeString, 5),
+						"string_contains":               advancedFilterSchema(schema.TypeString, 5),
+						"string_in":                     advancedFilterSchema(schema.TypeString, 5),
+						"string_not_in":                 advancedFilterSchema(schema.TypeString, 5),
+					},
+				},
+			},
+
+			"storage_blob_dead_letter_destination": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"storage_blob_container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"retry_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_delivery_attempts": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 30),
+						},
+
+						"event_time_to_live": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 1440),
+						},
+					},
+				},
+			},
+
+			"delivery_identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(eventgrid.SystemAssigned),
+								string(eventgrid.UserAssigned),
+							}, false),
+						},
+
+						"user_assigned_identity_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.UserAssignedIdentityID,
+						},
+					},
+				},
+			},
+
+			"labels": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+var eventSubscriptionEndpointProperties = []string{
+	"azure_function_endpoint",
+	"eventhub_endpoint_id",
+	"hybrid_connection_endpoint_id",
+	"service_bus_queue_endpoint_id",
+	"service_bus_topic_endpoint_id",
+	"storage_queue_endpoint",
+	"webhook_endpoint",
+}
+
+func advancedFilterSchema(valueType schema.ValueType, maxItems int) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"value": {
+					Type:     valueType,
+					Optional: true,
+				},
+
+				"values": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: maxItems,
+					Elem:     &schema.Schema{Type: valueType},
+				},
+			},
+		},
+	}
+}
+
+func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).EventGrid.SystemTopicEventSubscriptionsClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	systemTopic := d.Get("system_topic").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, systemTopic, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing Event Grid System Topic Event Subscription %q (System Topic %q / Resource Group %q): %s", name, systemTopic, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_eventgrid_system_topic_event_subscription", *existing.ID)
+		}
+	}
+
+	destination, err := expandEventGridSystemTopicEventSubscriptionDestination(d)
+	if err != nil {
+		return fmt.Errorf("expanding Event Grid System Topic Event Subscription destination: %+v", err)
+	}
+
+	eventSubscriptionProperties := eventgrid.EventSubscriptionProperties{
+		Destination:           destination,
+		Filter:                expandEventGridSystemTopicEventSubscriptionFilter(d),
+		DeadLetterDestination: expandEventGridSystemTopicEventSubscriptionStorageBlobDeadLetterDestination(d),
+		RetryPolicy:           expandEventGridSystemTopicEventSubscriptionRetryPolicy(d),
+		Labels:                utils.ExpandStringSlice(d.Get("labels").([]interface{})),
+		EventDeliverySchema:   eventgrid.EventDeliverySchema(d.Get("event_delivery_schema").(string)),
+	}
+
+	if v, ok := d.GetOk("expiration_time_utc"); ok {
+		expirationTimeUtc, err := date.ParseTime(time.RFC3339, v.(string))
+		if err != nil {
+			return fmt.Errorf("parsing `expiration_time_utc`: %+v", err)
+		}
+		eventSubscriptionProperties.ExpirationTimeUtc = &date.Time{Time: expirationTimeUtc}
+	}
+
+	if v, ok := d.GetOk("delivery_identity"); ok {
+		identity, err := expandEventGridSystemTopicEventSubscriptionIdentity(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		eventSubscriptionProperties.DeliveryWithResourceIdentity = &eventgrid.DeliveryWithResourceIdentity{
+			Identity:    identity,
+			Destination: destination,
+		}
+		eventSubscriptionProperties.Destination = nil
+	}
+
+	eventSubscription := eventgrid.EventSubscription{
+		EventSubscriptionProperties: &eventSubscriptionProperties,
+	}
+
+	log.Printf("[INFO] preparing arguments for AzureRM Event Grid System Topic Event Subscription creation with Properties: %+v.", eventSubscription)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, systemTopic, name, eventSubscription)
+	if err != nil {
+		return fmt.Errorf("creating/updating Event Grid System Topic Event Subscription %q (System Topic %q / Resource Group %q): %+v", name, systemTopic, resourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for Event Grid System Topic Event Subscription %q (System Topic %q / Resource Group %q) to be created/updated: %+v", name, systemTopic, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, systemTopic, name)
+	if err != nil {
+		return err
+	}
+	if read.ID == nil {
+		return fmt.Errorf("cannot read Event Grid System Topic Event Subscription %s (System Topic %s / Resource Group %s) ID", name, systemTopic, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceEventGridSystemTopicEventSubscriptionRead(d, meta)
+}
+
+func resourceEventGridSystemTopicEventSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).EventGrid.SystemTopicEventSubscriptionsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SystemTopicEventSubscriptionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[WARN] Event Grid System Topic Event Subscription %q was not found (System Topic %q / Resource Group %q)", id.Name, id.SystemTopic, id.ResourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("making Read request on Event Grid System Topic Event Subscription %q: %+v", id.Name, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("system_topic", id.SystemTopic)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if props := resp.EventSubscriptionProperties; props != nil {
+		d.Set("event_delivery_schema", string(props.EventDeliverySchema))
+
+		if props.ExpirationTimeUtc != nil {
+			d.Set("expiration_time_utc", props.ExpirationTimeUtc.Format(time.RFC3339))
+		}
+
+		destination := props.Destination
+		identity := []interface{}{}
+		if withIdentity := props.DeliveryWithResourceIdentity; withIdentity != nil {
+			destination = withIdentity.Destination
+			identity = flattenEventGridSystemTopicEventSubscriptionIdentity(withIdentity.Identity)
+		}
+		d.Set("delivery_identity", identity)
+
+		if err := flattenEventGridSystemTopicEventSubscriptionDestination(d, destination); err != nil {
+			return fmt.Errorf("flattening Event Grid System Topic Event Subscription destination: %+v", err)
+		}
+
+		if err := d.Set("storage_blob_dead_letter_destination", flattenEventGridSystemTopicEventSubscriptionStorageBlobDeadLetterDestination(props.DeadLetterDestination)); err != nil {
+			return fmt.Errorf("setting `storage_blob_dead_letter_destination`: %+v", err)
+		}
+
+		if err := d.Set("retry_policy", flattenEventGridSystemTopicEventSubscriptionRetryPolicy(props.RetryPolicy)); err != nil {
+			return fmt.Errorf("setting `retry_policy`: %+v", err)
+		}
+
+		if filter := props.Filter; filter != nil {
+			if err := d.Set("included_event_types", utils.FlattenStringSlice(filter.IncludedEventTypes)); err != nil {
+				return fmt.Errorf("setting `included_event_types`: %+v", err)
+			}
+
+			if err := d.Set("subject_filter", flattenEventGridSystemTopicEventSubscriptionSubjectFilter(filter)); err != nil {
+				return fmt.Errorf("setting `subject_filter`: %+v", err)
+			}
+
+			if err := d.Set("advanced_filter", flattenEventGridSystemTopicEventSubscriptionAdvancedFilter(filter)); err != nil {
+				return fmt.Errorf("setting `advanced_filter`: %+v", err)
+			}
+		}
+
+		if err := d.Set("labels", utils.FlattenStringSlice(props.Labels)); err != nil {
+			return fmt.Errorf("setting `labels`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceEventGridSystemTopicEventSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).EventGrid.SystemTopicEventSubscriptionsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SystemTopicEventSubscriptionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting Event Grid System Topic Event Subscription %q: %+v", id.Name, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of Event Grid System Topic Event Subscription %q: %+v", id.Name, err)
+	}
+
+	return nil
+}
+
+func expandEventGridSystemTopicEventSubscriptionDestination(d *schema.ResourceData) (eventgrid.BasicEventSubscriptionDestination, error) {
+	if v, ok := d.GetOk("azure_function_endpoint"); ok {
+		return expandEventGridSystemTopicEventSubscriptionAzureFunctionEndpoint(v.([]interface{})), nil
+	}
+
+	if v, ok := d.GetOk("eventhub_endpoint_id"); ok {
+		return eventgrid.EventHubEventSubscriptionDestination{
+			EndpointType: eventgrid.EndpointTypeEventHub,
+			EventHubEventSubscriptionDestinationProperties: &eventgrid.EventHubEventSubscriptionDestinationProperties{
+				ResourceID: utils.String(v.(string)),
+			},
+		}, nil
+	}
+
+	if v, ok := d.GetOk("hybrid_connection_endpoint_id"); ok {
+		return eventgrid.HybridConnectionEventSubscriptionDestination{
+			EndpointType: eventgrid.EndpointTypeHybridConnection,
+			HybridConnectionEventSubscriptionDestinationProperties: &eventgrid.HybridConnectionEventSubscriptionDestinationProperties{
+				ResourceID: utils.String(v.(string)),
+			},
+		}, nil
+	}
+
+	if v, ok := d.GetOk("service_bus_queue_endpoint_id"); ok {
+		return eventgrid.ServiceBusQueueEventSubscriptionDestination{
+			EndpointType: eventgrid.EndpointTypeServiceBusQueue,
+			ServiceBusQueueEventSubscriptionDestinationProperties: &eventgrid.ServiceBusQueueEventSubscriptionDestinationProperties{
+				ResourceID: utils.String(v.(string)),
+			},
+		}, nil
+	}
+
+	if v, ok := d.GetOk("service_bus_topic_endpoint_id"); ok {
+		return eventgrid.ServiceBusTopicEventSubscriptionDestination{
+			EndpointType: eventgrid.EndpointTypeServiceBusTopic,
+			ServiceBusTopicEventSubscriptionDestinationProperties: &eventgrid.ServiceBusTopicEventSubscriptionDestinationProperties{
+				ResourceID: utils.String(v.(string)),
+			},
+		}, nil
+	}
+
+	if v, ok := d.GetOk("storage_queue_endpoint"); ok {
+		return expandEventGridSystemTopicEventSubscriptionStorageQueueEndpoint(v.([]interface{})), nil
+	}
+
+	if v, ok := d.GetOk("webhook_endpoint"); ok {
+		return expandEventGridSystemTopicEventSubscriptionWebhookEndpoint(v.([]interface{})), nil
+	}
+
+	return nil, fmt.Errorf("one of `azure_function_endpoint`, `eventhub_endpoint_id`, `hybrid_connection_endpoint_id`, `service_bus_queue_endpoint_id`, `service_bus_topic_endpoint_id`, `storage_queue_endpoint` or `webhook_endpoint` must be specified")
+}
+
+func expandEventGridSystemTopicEventSubscriptionAzureFunctionEndpoint(input []interface{}) eventgrid.AzureFunctionEventSubscriptionDestination {
+	raw := input[0].(map[string]interface{})
+
+	return eventgrid.AzureFunctionEventSubscriptionDestination{
+		EndpointType: eventgrid.EndpointTypeAzureFunction,
+		AzureFunctionEventSubscriptionDestinationProperties: &eventgrid.AzureFunctionEventSubscriptionDestinationProperties{
+			ResourceID:                    utils.String(raw["function_id"].(string)),
+			MaxEventsPerBatch:             utils.Int32(int32(raw["max_events_per_batch"].(int))),
+			PreferredBatchSizeInKilobytes: utils.Int32(int32(raw["preferred_batch_size_in_kilobytes"].(int))),
+		},
+	}
+}
+
+func expandEventGridSystemTopicEventSubscriptionStorageQueueEndpoint(input []interface{}) eventgrid.StorageQueueEventSubscriptionDestination {
+	raw := input[0].(map[string]interface{})
+
+	destination := eventgrid.StorageQueueEventSubscriptionDestination{
+		EndpointType: eventgrid.EndpointTypeStorageQueue,
+		StorageQueueEventSubscriptionDestinationProperties: &eventgrid.StorageQueueEventSubscriptionDestinationProperties{
+			ResourceID: utils.String(raw["storage_account_id"].(string)),
+			QueueName:  utils.String(raw["queue_name"].(string)),
+		},
+	}
+
+	if v, ok := raw["queue_message_time_to_live_in_seconds"]; ok && v.(int) > 0 {
+		destination.StorageQueueEventSubscriptionDestinationProperties.QueueMessageTimeToLiveInSeconds = utils.Int64(int64(v.(int)))
+	}
+
+	return destination
+}
+
+func expandEventGridSystemTopicEventSubscriptionWebhookEndpoint(input []interface{}) eventgrid.WebHookEventSubscriptionDestination {
+	raw := input[0].(map[string]interface{})
+
+	destination := eventgrid.WebHookEventSubscriptionDestination{
+		EndpointType: eventgrid.EndpointTypeWebHook,
+		WebHookEventSubscriptionDestinationProperties: &eventgrid.WebHookEventSubscriptionDestinationProperties{
+			EndpointURL:                   utils.String(raw["url"].(string)),
+			MaxEventsPerBatch:             utils.Int32(int32(raw["max_events_per_batch"].(int))),
+			PreferredBatchSizeInKilobytes: utils.Int32(int32(raw["preferred_batch_size_in_kilobytes"].(int))),
+		},
+	}
+
+	if v := raw["active_directory_tenant_id"].(string); v != "" {
+		destination.WebHookEventSubscriptionDestinationProperties.AzureActiveDirectoryTenantID = utils.String(v)
+	}
+
+	if v := raw["active_directory_app_id_or_uri"].(string); v != "" {
+		destination.WebHookEventSubscriptionDestinationProperties.AzureActiveDirectoryApplicationIDOrURI = utils.String(v)
+	}
+
+	return destination
+}
+
+func flattenEventGridSystemTopicEventSubscriptionDestination(d *schema.ResourceData, input eventgrid.BasicEventSubscriptionDestination) error {
+	if input == nil {
+		return nil
+	}
+
+	switch destination := input.(type) {
+	case eventgrid.AzureFunctionEventSubscriptionDestination:
+		props := destination.AzureFunctionEventSubscriptionDestinationProperties
+		functionID := ""
+		maxEventsPerBatch := 0
+		preferredBatchSizeInKilobytes := 0
+		if props != nil {
+			if props.ResourceID != nil {
+				functionID = *props.ResourceID
+			}
+			if props.MaxEventsPerBatch != nil {
+				maxEventsPerBatch = int(*props.MaxEventsPerBatch)
+			}
+			if props.PreferredBatchSizeInKilobytes != nil {
+				preferredBatchSizeInKilobytes = int(*props.PreferredBatchSizeInKilobytes)
+			}
+		}
+
+		return d.Set("azure_function_endpoint", []interface{}{
+			map[string]interface{}{
+				"function_id":                       functionID,
+				"max_events_per_batch":              maxEventsPerBatch,
+				"preferred_batch_size_in_kilobytes": preferredBatchSizeInKilobytes,
+			},
+		})
+
+	case eventgrid.EventHubEventSubscriptionDestination:
+		resourceID := ""
+		if props := destination.EventHubEventSubscriptionDestinationProperties; props != nil && props.ResourceID != nil {
+			resourceID = *props.ResourceID
+		}
+		return d.Set("eventhub_endpoint_id", resourceID)
+
+	case eventgrid.HybridConnectionEventSubscriptionDestination:
+		resourceID := ""
+		if props := destination.HybridConnectionEventSubscriptionDestinationProperties; props != nil && props.ResourceID != nil {
+			resourceID = *props.ResourceID
+		}
+		return d.Set("hybrid_connection_endpoint_id", resourceID)
+
+	case eventgrid.ServiceBusQueueEventSubscriptionDestination:
+		resourceID := ""
+		if props := destination.ServiceBusQueueEventSubscriptionDestinationProperties; props != nil && props.ResourceID != nil {
+			resourceID = *props.ResourceID
+		}
+		return d.Set("service_bus_queue_endpoint_id", resourceID)
+
+	case eventgrid.ServiceBusTopicEventSubscriptionDestination:
+		resourceID := ""
+		if props := destination.ServiceBusTopicEventSubscriptionDestinationProperties; props != nil && props.ResourceID != nil {
+			resourceID = *props.ResourceID
+		}
+		return d.Set("service_bus_topic_endpoint_id", resourceID)
+
+	case eventgrid.StorageQueueEventSubscriptionDestination:
+		storageAccountID := ""
+		queueName := ""
+		queueMessageTimeToLiveInSeconds := 0
+		if props := destination.StorageQueueEventSubscriptionDestinationProperties; props != nil {
+			if props.ResourceID != nil {
+				storageAccountID = *props.ResourceID
+			}
+			if props.QueueName != nil {
+				queueName = *props.QueueName
+			}
+			if props.QueueMessageTimeToLiveInSeconds != nil {
+				queueMessageTimeToLiveInSeconds = int(*props.QueueMessageTimeToLiveInSeconds)
+			}
+		}
+
+		return d.Set("storage_queue_endpoint", []interface{}{
+			map[string]interface{}{
+				"storage_account_id":                    storageAccountID,
+				"queue_name":                            queueName,
+				"queue_message_time_to_live_in_seconds": queueMessageTimeToLiveInSeconds,
+			},
+		})
+
+	case eventgrid.WebHookEventSubscriptionDestination:
+		url := ""
+		baseURL := ""
+		maxEventsPerBatch := 0
+		preferredBatchSizeInKilobytes := 0
+		tenantID := ""
+		appIDOrURI := ""
+		if props := destination.WebHookEventSubscriptionDestinationProperties; props != nil {
+			if props.EndpointURL != nil {
+				url = *props.EndpointURL
+			}
+			if props.EndpointBaseURL != nil {
+				baseURL = *props.EndpointBaseURL
+			}
+			if props.MaxEventsPerBatch != nil {
+				maxEventsPerBatch = int(*props.MaxEventsPerBatch)
+			}
+			if props.PreferredBatchSizeInKilobytes != nil {
+				preferredBatchSizeInKilobytes = int(*props.PreferredBatchSizeInKilobytes)
+			}
+			if props.AzureActiveDirectoryTenantID != nil {
+				tenantID = *props.AzureActiveDirectoryTenantID
+			}
+			if props.AzureActiveDirectoryApplicationIDOrURI != nil {
+				appIDOrURI = *props.AzureActiveDirectoryApplicationIDOrURI
+			}
+		}
+
+		return d.Set("webhook_endpoint", []interface{}{
+			map[string]interface{}{
+				"url":                               url,
+				"base_url":                          baseURL,
+				"max_events_per_batch":              maxEventsPerBatch,
+				"preferred_batch_size_in_kilobytes": preferredBatchSizeInKilobytes,
+				"active_directory_tenant_id":        tenantID,
+				"active_directory_app_id_or_uri":    appIDOrURI,
+			},
+		})
+	}
+
+	return nil
+}
+
+func expandEventGridSystemTopicEventSubscriptionFilter(d *schema.ResourceData) *eventgrid.EventSubscriptionFilter {
+	filter := &eventgrid.EventSubscriptionFilter{
+		IncludedEventTypes: utils.ExpandStringSlice(d.Get("included_event_types").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("subject_filter"); ok {
+		raw := v.([]interface{})[0].(map[string]interface{})
+		filter.SubjectBeginsWith = utils.String(raw["subject_begins_with"].(string))
+		filter.SubjectEndsWith = utils.String(raw["subject_ends_with"].(string))
+		filter.IsSubjectCaseSensitive = utils.Bool(raw["case_sensitive"].(bool))
+	}
+
+	if v, ok := d.GetOk("advanced_filter"); ok {
+		filter.AdvancedFilters = expandEventGridSystemTopicEventSubscriptionAdvancedFilter(v.([]interface{}))
+	}
+
+	return filter
+}
+
+func expandEventGridSystemTopicEventSubscriptionAdvancedFilter(input []interface{}) *[]eventgrid.BasicAdvancedFilter {
+	filters := make([]eventgrid.BasicAdvancedFilter, 0)
+	if len(input) == 0 || input[0] == nil {
+		return &filters
+	}
+
+	raw := input[0].(map[string]interface{})
+
+	for _, item := range raw["bool_equals"].([]interface{}) {
+		v := item.(map[string]interface{})
+		filters = append(filters, eventgrid.BoolEqualsAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Value:        utils.Bool(v["value"].(bool)),
+			OperatorType: eventgrid.OperatorTypeBoolEquals,
+		})
+	}
+
+	for _, item := range raw["number_greater_than"].([]interface{}) {
+		v := item.(map[string]interface{})
+		filters = append(filters, eventgrid.NumberGreaterThanAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Value:        utils.Float(v["value"].(float64)),
+			OperatorType: eventgrid.OperatorTypeNumberGreaterThan,
+		})
+	}
+
+	for _, item := range raw["number_greater_than_or_equals"].([]interface{}) {
+		v := item.(map[string]interface{})
+		filters = append(filters, eventgrid.NumberGreaterThanOrEqualsAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Value:        utils.Float(v["value"].(float64)),
+			OperatorType: eventgrid.OperatorTypeNumberGreaterThanOrEquals,
+		})
+	}
+
+	for _, item := range raw["number_less_than"].([]interface{}) {
+		v := item.(map[string]interface{})
+		filters = append(filters, eventgrid.NumberLessThanAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Value:        utils.Float(v["value"].(float64)),
+			OperatorType: eventgrid.OperatorTypeNumberLessThan,
+		})
+	}
+
+	for _, item := range raw["number_less_than_or_equals"].([]interface{}) {
+		v := item.(map[string]interface{})
+		filters = append(filters, eventgrid.NumberLessThanOrEqualsAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Value:        utils.Float(v["value"].(float64)),
+			OperatorType: eventgrid.OperatorTypeNumberLessThanOrEquals,
+		})
+	}
+
+	for _, item := range raw["number_in"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := make([]float64, 0)
+		for _, value := range v["values"].([]interface{}) {
+			values = append(values, value.(float64))
+		}
+		filters = append(filters, eventgrid.NumberInAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       &values,
+			OperatorType: eventgrid.OperatorTypeNumberIn,
+		})
+	}
+
+	for _, item := range raw["number_not_in"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := make([]float64, 0)
+		for _, value := range v["values"].([]interface{}) {
+			values = append(values, value.(float64))
+		}
+		filters = append(filters, eventgrid.NumberNotInAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       &values,
+			OperatorType: eventgrid.OperatorTypeNumberNotIn,
+		})
+	}
+
+	for _, item := range raw["string_begins_with"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := utils.ExpandStringSlice(v["values"].([]interface{}))
+		filters = append(filters, eventgrid.StringBeginsWithAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       values,
+			OperatorType: eventgrid.OperatorTypeStringBeginsWith,
+		})
+	}
+
+	for _, item := range raw["string_ends_with"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := utils.ExpandStringSlice(v["values"].([]interface{}))
+		filters = append(filters, eventgrid.StringEndsWithAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       values,
+			OperatorType: eventgrid.OperatorTypeStringEndsWith,
+		})
+	}
+
+	for _, item := range raw["string_contains"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := utils.ExpandStringSlice(v["values"].([]interface{}))
+		filters = append(filters, eventgrid.StringContainsAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       values,
+			OperatorType: eventgrid.OperatorTypeStringContains,
+		})
+	}
+
+	for _, item := range raw["string_in"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := utils.ExpandStringSlice(v["values"].([]interface{}))
+		filters = append(filters, eventgrid.StringInAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       values,
+			OperatorType: eventgrid.OperatorTypeStringIn,
+		})
+	}
+
+	for _, item := range raw["string_not_in"].([]interface{}) {
+		v := item.(map[string]interface{})
+		values := utils.ExpandStringSlice(v["values"].([]interface{}))
+		filters = append(filters, eventgrid.StringNotInAdvancedFilter{
+			Key:          utils.String(v["key"].(string)),
+			Values:       values,
+			OperatorType: eventgrid.OperatorTypeStringNotIn,
+		})
+	}
+
+	return &filters
+}
+
+func flattenEventGridSystemTopicEventSubscriptionSubjectFilter(filter *eventgrid.EventSubscriptionFilter) []interface{} {
+	if filter == nil || (filter.SubjectBeginsWith == nil && filter.SubjectEndsWith == nil) {
+		return []interface{}{}
+	}
+
+	beginsWith := ""
+	if filter.SubjectBeginsWith != nil {
+		beginsWith = *filter.SubjectBeginsWith
+	}
+
+	endsWith := ""
+	if filter.SubjectEndsWith != nil {
+		endsWith = *filter.SubjectEndsWith
+	}
+
+	caseSensitive := false
+	if filter.IsSubjectCaseSensitive != nil {
+		caseSensitive = *filter.IsSubjectCaseSensitive
+	}
+
+	if beginsWith == "" && endsWith == "" && !caseSensitive {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"subject_begins_with": beginsWith,
+			"subject_ends_with":   endsWith,
+			"case_sensitive":      caseSensitive,
+		},
+	}
+}
+
+func flattenEventGridSystemTopicEventSubscriptionAdvancedFilter(filter *eventgrid.EventSubscriptionFilter) []interface{} {
+	if filter == nil || filter.AdvancedFilters == nil || len(*filter.AdvancedFilters) == 0 {
+		return []interface{}{}
+	}
+
+	result := map[string]interface{}{
+		"bool_equals":                   []interface{}{},
+		"number_greater_than":           []interface{}{},
+		"number_greater_than_or_equals": []interface{}{},
+		"number_less_than":              []interface{}{},
+		"number_less_than_or_equals":    []interface{}{},
+		"number_in":                     []interface{}{},
+		"number_not_in":                 []interface{}{},
+		"string_begins_with":            []interface{}{},
+		"string_ends_with":              []interface{}{},
+		"string_contains":               []interface{}{},
+		"string_in":                     []interface{}{},
+		"string_not_in":                 []interface{}{},
+	}
+
+	for _, filter := range *filter.AdvancedFilters {
+		switch f := filter.(type) {
+		case eventgrid.BoolEqualsAdvancedFilter:
+			result["bool_equals"] = append(result["bool_equals"].([]interface{}), map[string]interface{}{
+				"key":   safeStringValue(f.Key),
+				"value": f.Value != nil && *f.Value,
+			})
+		case eventgrid.NumberGreaterThanAdvancedFilter:
+			result["number_greater_than"] = append(result["number_greater_than"].([]interface{}), map[string]interface{}{
+				"key":   safeStringValue(f.Key),
+				"value": safeFloatValue(f.Value),
+			})
+		case eventgrid.NumberGreaterThanOrEqualsAdvancedFilter:
+			result["number_greater_than_or_equals"] = append(result["number_greater_than_or_equals"].([]interface{}), map[string]interface{}{
+				"key":   safeStringValue(f.Key),
+				"value": safeFloatValue(f.Value),
+			})
+		case eventgrid.NumberLessThanAdvancedFilter:
+			result["number_less_than"] = append(result["number_less_than"].([]interface{}), map[string]interface{}{
+				"key":   safeStringValue(f.Key),
+				"value": safeFloatValue(f.Value),
+			})
+		case eventgrid.NumberLessThanOrEqualsAdvancedFilter:
+			result["number_less_than_or_equals"] = append(result["number_less_than_or_equals"].([]interface{}), map[string]interface{}{
+				"key":   safeStringValue(f.Key),
+				"value": safeFloatValue(f.Value),
+			})
+		case eventgrid.NumberInAdvancedFilter:
+			values := []interface{}{}
+			if f.Values != nil {
+				for _, v := range *f.Values {
+					values = append(values, v)
+				}
+			}
+			result["number_in"] = append(result["number_in"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": values,
+			})
+		case eventgrid.NumberNotInAdvancedFilter:
+			values := []interface{}{}
+			if f.Values != nil {
+				for _, v := range *f.Values {
+					values = append(values, v)
+				}
+			}
+			result["number_not_in"] = append(result["number_not_in"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": values,
+			})
+		case eventgrid.StringBeginsWithAdvancedFilter:
+			result["string_begins_with"] = append(result["string_begins_with"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": utils.FlattenStringSlice(f.Values),
+			})
+		case eventgrid.StringEndsWithAdvancedFilter:
+			result["string_ends_with"] = append(result["string_ends_with"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": utils.FlattenStringSlice(f.Values),
+			})
+		case eventgrid.StringContainsAdvancedFilter:
+			result["string_contains"] = append(result["string_contains"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": utils.FlattenStringSlice(f.Values),
+			})
+		case eventgrid.StringInAdvancedFilter:
+			result["string_in"] = append(result["string_in"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": utils.FlattenStringSlice(f.Values),
+			})
+		case eventgrid.StringNotInAdvancedFilter:
+			result["string_not_in"] = append(result["string_not_in"].([]interface{}), map[string]interface{}{
+				"key":    safeStringValue(f.Key),
+				"values": utils.FlattenStringSlice(f.Values),
+			})
+		}
+	}
+
+	return []interface{}{result}
+}
+
+func safeStringValue(input *string) string {
+	if input == nil {
+		return ""
+	}
+	return *input
+}
+
+func safeFloatValue(input *float64) float64 {
+	if input == nil {
+		return 0
+	}
+	return *input
+}
+
+func expandEventGridSystemTopicEventSubscriptionStorageBlobDeadLetterDestination(d *schema.ResourceData) eventgrid.BasicDeadLetterDestination {
+	v, ok := d.GetOk("storage_blob_dead_letter_destination")
+	if !ok {
+		return nil
+	}
+
+	raw := v.([]interface{})[0].(map[string]interface{})
+
+	return eventgrid.StorageBlobDeadLetterDestination{
+		EndpointType: eventgrid.EndpointTypeStorageBlob,
+		StorageBlobDeadLetterDestinationProperties: &eventgrid.StorageBlobDeadLetterDestinationProperties{
+			ResourceID:        utils.String(raw["storage_account_id"].(string)),
+			BlobContainerName: utils.String(raw["storage_blob_container_name"].(string)),
+		},
+	}
+}
+
+func flattenEventGridSystemTopicEventSubscriptionStorageBlobDeadLetterDestination(input eventgrid.BasicDeadLetterDestination) []interface{} {
+	destination, ok := input.(eventgrid.StorageBlobDeadLetterDestination)
+	if !ok || destination.StorageBlobDeadLetterDestinationProperties == nil {
+		return []interface{}{}
+	}
+
+	props := destination.StorageBlobDeadLetterDestinationProperties
+
+	storageAccountID := ""
+	if props.ResourceID != nil {
+		storageAccountID = *props.ResourceID
+	}
+
+	containerName := ""
+	if props.BlobContainerName != nil {
+		containerName = *props.BlobContainerName
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"storage_account_id":          storageAccountID,
+			"storage_blob_container_name": containerName,
+		},
+	}
+}
+
+func expandEventGridSystemTopicEventSubscriptionRetryPolicy(d *schema.ResourceData) *eventgrid.RetryPolicy {
+	v, ok := d.GetOk("retry_policy")
+	if !ok {
+		return nil
+	}
+
+	raw := v.([]interface{})[0].(map[string]interface{})
+
+	return &eventgrid.RetryPolicy{
+		MaxDeliveryAttempts:      utils.Int32(int32(raw["max_delivery_attempts"].(int))),
+		EventTimeToLiveInMinutes: utils.Int32(int32(raw["event_time_to_live"].(int))),
+	}
+}
+
+func flattenEventGridSystemTopicEventSubscriptionRetryPolicy(input *eventgrid.RetryPolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	maxDeliveryAttempts := 0
+	if input.MaxDeliveryAttempts != nil {
+		maxDeliveryAttempts = int(*input.MaxDeliveryAttempts)
+	}
+
+	eventTimeToLive := 0
+	if input.EventTimeToLiveInMinutes != nil {
+		eventTimeToLive = int(*input.EventTimeToLiveInMinutes)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"max_delivery_attempts": maxDeliveryAttempts,
+			"event_time_to_live":    eventTimeToLive,
+		},
+	}
+}
+
+func expandEventGridSystemTopicEventSubscriptionIdentity(input []interface{}) (*eventgrid.EventSubscriptionIdentity, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+
+	identity := eventgrid.EventSubscriptionIdentity{
+		Type: eventgrid.EventSubscriptionIdentityType(raw["type"].(string)),
+	}
+
+	userAssignedIdentityID := raw["user_assigned_identity_id"].(string)
+	if identity.Type == eventgrid.EventSubscriptionIdentityTypeUserAssigned {
+		if userAssignedIdentityID == "" {
+			return nil, fmt.Errorf("`user_assigned_identity_id` must be specified when `type` is `UserAssigned`")
+		}
+		identity.UserAssignedIdentity = utils.String(userAssignedIdentityID)
+	}
+
+	return &identity, nil
+}
+
+func flattenEventGridSystemTopicEventSubscriptionIdentity(input *eventgrid.EventSubscriptionIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	userAssignedIdentityID := ""
+	if input.UserAssignedIdentity != nil {
+		userAssignedIdentityID = *input.UserAssignedIdentity
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":                      string(input.Type),
+			"user_assigned_identity_id": userAssignedIdentityID,
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/response"
@@ -12,6 +13,7 @@ import (
 	"github.com/jcanizalez/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/eventgrid/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -118,9 +120,21 @@ func resourceEventGridSystemTopic() *schema.Resource {
 							Optional:         true,
 							DiffSuppressFunc: suppress.CaseDifference,
 							ValidateFunc: validation.StringInSlice([]string{
-								"SystemAssigned",
+								string(eventgrid.SystemAssigned),
+								string(eventgrid.SystemAssignedUserAssigned),
+								string(eventgrid.UserAssigned),
 							}, true),
 						},
+
+						"identity_ids": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validate.UserAssignedIdentityID,
+							},
+						},
 					},
 				},
 			},
@@ -166,7 +180,11 @@ func resourceEventGridSystemTopicCreateUpdate(d *schema.ResourceData, meta inter
 	}
 
 	if v, ok := d.GetOk("identity"); ok {
-		systemTopic.Identity = expandSystemTopicIdentity(v.([]interface{}))
+		identity, err := expandSystemTopicIdentity(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		systemTopic.Identity = identity
 	}
 
 	log.Printf("[INFO] preparing arguments for AzureRM Event Grid System Topic creation with Properties: %+v.", systemTopic)
@@ -259,11 +277,11 @@ func resourceEventGridSystemTopicDelete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func expandSystemTopicIdentity(input []interface{}) *eventgrid.SystemTopicIdentity {
+func expandSystemTopicIdentity(input []interface{}) (*eventgrid.SystemTopicIdentity, error) {
 	if len(input) == 0 {
 		return &eventgrid.SystemTopicIdentity{
 			Type: eventgrid.ManagedIdentityTypeNone,
-		}
+		}, nil
 	}
 
 	raw := input[0].(map[string]interface{})
@@ -272,7 +290,21 @@ func expandSystemTopicIdentity(input []interface{}) *eventgrid.SystemTopicIdenti
 		Type: eventgrid.ManagedIdentityType(raw["type"].(string)),
 	}
 
-	return &identity
+	identityIdsRaw := raw["identity_ids"].([]interface{})
+	identityIds := make(map[string]*eventgrid.UserIdentityProperties)
+	for _, v := range identityIdsRaw {
+		identityIds[v.(string)] = &eventgrid.UserIdentityProperties{}
+	}
+
+	if len(identityIds) > 0 {
+		if identity.Type != eventgrid.UserAssigned && identity.Type != eventgrid.SystemAssignedUserAssigned {
+			return nil, fmt.Errorf("`identity_ids` can only be specified when `type` is `UserAssigned` or `SystemAssigned, UserAssigned`")
+		}
+
+		identity.UserAssignedIdentities = identityIds
+	}
+
+	return &identity, nil
 }
 
 func flattenSystemTopicIdentity(input *eventgrid.SystemTopicIdentity) []interface{} {
@@ -290,9 +322,16 @@ func flattenSystemTopicIdentity(input *eventgrid.SystemTopicIdentity) []interfac
 		tenantID = *input.TenantID
 	}
 
+	identityIds := make([]string, 0)
+	for id := range input.UserAssignedIdentities {
+		identityIds = append(identityIds, id)
+	}
+	sort.Strings(identityIds)
+
 	return []interface{}{
 		map[string]interface{}{
 			"type":         string(input.Type),
+			"identity_ids": identityIds,
 			"principal_id": principalID,
 			"tenant_id":    tenantID,
 		},
@@ -0,0 +1,38 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type SystemTopicEventSubscriptionId struct {
+	ResourceGroup string
+	SystemTopic   string
+	Name          string
+}
+
+func SystemTopicEventSubscriptionID(input string) (*SystemTopicEventSubscriptionId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Event Grid System Topic Event Subscription ID %q: %+v", input, err)
+	}
+
+	systemTopicEventSubscription := SystemTopicEventSubscriptionId{
+		ResourceGroup: id.ResourceGroup,
+	}
+
+	if systemTopicEventSubscription.SystemTopic, err = id.PopSegment("systemTopics"); err != nil {
+		return nil, err
+	}
+
+	if systemTopicEventSubscription.Name, err = id.PopSegment("eventSubscriptions"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &systemTopicEventSubscription, nil
+}